@@ -0,0 +1,29 @@
+package errors
+
+import "fmt"
+
+// TaskfileLockfileMismatchError is returned when a remote Taskfile's checksum
+// does not match the digest pinned in the lockfile.
+type TaskfileLockfileMismatchError struct {
+	URI              string
+	Algorithm        string
+	ExpectedChecksum string
+	ActualChecksum   string
+}
+
+func (err *TaskfileLockfileMismatchError) Error() string {
+	return fmt.Sprintf(
+		"task: Remote Taskfile %q does not match the pinned lockfile entry\n--- expected (%s): %s\n--- actual   (%s): %s\nUpdate the lockfile if this change is expected, or investigate the source if it is not.",
+		err.URI, err.Algorithm, err.ExpectedChecksum, err.Algorithm, err.ActualChecksum,
+	)
+}
+
+// TaskfileLockfileMissingEntryError is returned when the lockfile has no entry
+// for a remote include and the reader was run with a frozen lockfile.
+type TaskfileLockfileMissingEntryError struct {
+	URI string
+}
+
+func (err *TaskfileLockfileMissingEntryError) Error() string {
+	return fmt.Sprintf("task: No lockfile entry found for remote Taskfile %q and the lockfile is frozen", err.URI)
+}
@@ -0,0 +1,15 @@
+package errors
+
+import "fmt"
+
+// TaskfileNetworkServerError is returned when a remote Taskfile fetch gets a
+// response the server identifies as transient (429 or any 5xx), so the
+// caller can distinguish it from a permanent 4xx failure and retry it.
+type TaskfileNetworkServerError struct {
+	URI        string
+	StatusCode int
+}
+
+func (err *TaskfileNetworkServerError) Error() string {
+	return fmt.Sprintf("task: got HTTP %d fetching %q", err.StatusCode, err.URI)
+}
@@ -0,0 +1,15 @@
+package errors
+
+import "fmt"
+
+// TaskfileSignatureError is returned when a remote Taskfile's detached
+// signature is missing (with --require-signed) or fails verification against
+// its trusted public key.
+type TaskfileSignatureError struct {
+	URI    string
+	Reason string
+}
+
+func (err *TaskfileSignatureError) Error() string {
+	return fmt.Sprintf("task: Remote Taskfile %q failed signature verification: %s", err.URI, err.Reason)
+}
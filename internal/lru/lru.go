@@ -0,0 +1,81 @@
+// Package lru implements a small, size-bounded, thread-safe LRU cache.
+package lru
+
+import (
+	"container/list"
+	"sync"
+)
+
+type entry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// Cache is a size-bounded least-recently-used cache. The zero value is not
+// usable; construct one with New.
+type Cache[K comparable, V any] struct {
+	mu       sync.Mutex
+	maxLen   int
+	ll       *list.List
+	elements map[K]*list.Element
+}
+
+// New creates a Cache that holds at most maxLen entries, evicting the least
+// recently used entry once that limit is exceeded. A maxLen <= 0 means
+// unbounded.
+func New[K comparable, V any](maxLen int) *Cache[K, V] {
+	return &Cache[K, V]{
+		maxLen:   maxLen,
+		ll:       list.New(),
+		elements: make(map[K]*list.Element),
+	}
+}
+
+// Get returns the value stored for key, if any, marking it as recently used.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.elements[key]; ok {
+		c.ll.MoveToFront(el)
+		return el.Value.(*entry[K, V]).value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Add inserts or updates the value stored for key, evicting the least
+// recently used entry if the cache is now over capacity.
+func (c *Cache[K, V]) Add(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.elements[key]; ok {
+		el.Value.(*entry[K, V]).value = value
+		c.ll.MoveToFront(el)
+		return
+	}
+
+	el := c.ll.PushFront(&entry[K, V]{key: key, value: value})
+	c.elements[key] = el
+
+	if c.maxLen > 0 && c.ll.Len() > c.maxLen {
+		c.removeOldest()
+	}
+}
+
+// Len returns the number of entries currently cached.
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ll.Len()
+}
+
+func (c *Cache[K, V]) removeOldest() {
+	el := c.ll.Back()
+	if el == nil {
+		return
+	}
+	c.ll.Remove(el)
+	delete(c.elements, el.Value.(*entry[K, V]).key)
+}
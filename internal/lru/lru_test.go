@@ -0,0 +1,62 @@
+package lru
+
+import "testing"
+
+func TestCacheGetMiss(t *testing.T) {
+	c := New[string, int](2)
+	if _, ok := c.Get("missing"); ok {
+		t.Fatalf("Get: got ok=true for a key that was never added")
+	}
+}
+
+func TestCacheAddAndGet(t *testing.T) {
+	c := New[string, int](2)
+	c.Add("a", 1)
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Fatalf("Get(a) = (%d, %v), want (1, true)", v, ok)
+	}
+}
+
+func TestCacheAddOverwritesExistingKey(t *testing.T) {
+	c := New[string, int](2)
+	c.Add("a", 1)
+	c.Add("a", 2)
+	if v, ok := c.Get("a"); !ok || v != 2 {
+		t.Fatalf("Get(a) = (%d, %v), want (2, true)", v, ok)
+	}
+	if got := c.Len(); got != 1 {
+		t.Fatalf("Len() = %d, want 1 (overwrite should not add a second entry)", got)
+	}
+}
+
+func TestCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	c := New[string, int](2)
+	c.Add("a", 1)
+	c.Add("b", 2)
+	// Touch "a" so "b" becomes the least recently used entry.
+	c.Get("a")
+	c.Add("c", 3)
+
+	if _, ok := c.Get("b"); ok {
+		t.Fatalf("Get(b): expected b to have been evicted")
+	}
+	if _, ok := c.Get("a"); !ok {
+		t.Fatalf("Get(a): expected a to still be cached (it was touched before the eviction)")
+	}
+	if _, ok := c.Get("c"); !ok {
+		t.Fatalf("Get(c): expected c to be cached")
+	}
+	if got := c.Len(); got != 2 {
+		t.Fatalf("Len() = %d, want 2", got)
+	}
+}
+
+func TestCacheUnboundedWhenMaxLenNotPositive(t *testing.T) {
+	c := New[string, int](0)
+	for i := 0; i < 100; i++ {
+		c.Add(string(rune('a'+i%26))+string(rune(i)), i)
+	}
+	if got := c.Len(); got != 100 {
+		t.Fatalf("Len() = %d, want 100 (maxLen <= 0 should mean unbounded)", got)
+	}
+}
@@ -0,0 +1,179 @@
+// Package xfer implements a bounded, deduplicating transfer manager for
+// fetching remote resources, modelled after the download/transfer manager
+// used by Docker's image puller. It sits between a caller (e.g.
+// taskfile.Reader) and the thing that actually performs a fetch, adding
+// worker-pool concurrency limits, per-key deduplication, retry with
+// exponential backoff and jitter, and progress reporting.
+package xfer
+
+import (
+	"context"
+	"math/rand"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// Reporter receives progress events for fetches performed by a Manager. All
+// methods may be called concurrently from multiple goroutines.
+type Reporter interface {
+	// OnQueued is called when a fetch is requested, before it has a worker
+	// slot. queued is the number of Fetch calls that have not yet returned,
+	// including ones that already hold a slot or wait on a shared fetch.
+	OnQueued(key string, queued int64)
+	// OnStart is called once a fetch acquires a worker slot and begins.
+	OnStart(key string, active, queued int64)
+	// OnRetry is called before a retry attempt, with the error that caused it.
+	OnRetry(key string, attempt int, err error)
+	// OnComplete is called once a fetch finishes, successfully or not.
+	OnComplete(key string, active int64, err error)
+}
+
+// NopReporter is a Reporter that discards every event.
+type NopReporter struct{}
+
+func (NopReporter) OnQueued(string, int64)          {}
+func (NopReporter) OnStart(string, int64, int64)    {}
+func (NopReporter) OnRetry(string, int, error)      {}
+func (NopReporter) OnComplete(string, int64, error) {}
+
+// Options configures a Manager.
+type Options struct {
+	// Concurrency is the maximum number of fetches that may run at once.
+	// Defaults to 5 when <= 0.
+	Concurrency int
+	// MaxRetries is the number of retry attempts after an initial failed
+	// attempt. Defaults to 3 when < 0.
+	MaxRetries int
+	// BaseBackoff is the starting delay for exponential backoff. Defaults to
+	// 250ms when <= 0.
+	BaseBackoff time.Duration
+	// MaxBackoff caps the backoff delay. Defaults to 10s when <= 0.
+	MaxBackoff time.Duration
+	// Retryable reports whether an error from a fetch should be retried.
+	// Defaults to always retrying.
+	Retryable func(error) bool
+	// Reporter receives progress events. Defaults to NopReporter.
+	Reporter Reporter
+}
+
+func (o *Options) setDefaults() {
+	if o.Concurrency <= 0 {
+		o.Concurrency = 5
+	}
+	if o.MaxRetries < 0 {
+		o.MaxRetries = 3
+	}
+	if o.BaseBackoff <= 0 {
+		o.BaseBackoff = 250 * time.Millisecond
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = 10 * time.Second
+	}
+	if o.Retryable == nil {
+		o.Retryable = func(error) bool { return true }
+	}
+	if o.Reporter == nil {
+		o.Reporter = NopReporter{}
+	}
+}
+
+// Manager is a bounded worker pool that fetches keyed resources of type T,
+// deduplicating concurrent fetches for the same key and retrying transient
+// failures with exponential backoff and jitter.
+type Manager[T any] struct {
+	opts   Options
+	sem    chan struct{}
+	sf     singleflight.Group
+	queued atomic.Int64
+	active atomic.Int64
+}
+
+// NewManager creates a Manager with the given Options, applying defaults for
+// any zero-valued fields.
+func NewManager[T any](opts Options) *Manager[T] {
+	opts.setDefaults()
+	return &Manager[T]{
+		opts: opts,
+		sem:  make(chan struct{}, opts.Concurrency),
+	}
+}
+
+// Fetch runs fn to produce the value for key, sharing a single in-flight
+// fetch across concurrent callers that request the same key and retrying on
+// transient errors per Options.Retryable. It blocks until a worker slot is
+// free, a result is available, or ctx is cancelled.
+func (m *Manager[T]) Fetch(ctx context.Context, key string, fn func(ctx context.Context) (T, error)) (T, error) {
+	m.queued.Add(1)
+	m.opts.Reporter.OnQueued(key, m.queued.Load())
+	// Every caller that incremented queued above decrements it here once
+	// sf.Do returns, whether it was the singleflight leader that actually
+	// ran fn or a follower that only waited on the leader's result -
+	// incrementing per-caller but decrementing only inside the leader's
+	// closure would leak the counter for every deduplicated follower.
+	defer m.queued.Add(-1)
+
+	v, err, _ := m.sf.Do(key, func() (any, error) {
+		select {
+		case m.sem <- struct{}{}:
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+		m.active.Add(1)
+		m.opts.Reporter.OnStart(key, m.active.Load(), m.queued.Load())
+		defer func() {
+			<-m.sem
+			m.active.Add(-1)
+		}()
+
+		result, err := m.fetchWithRetry(ctx, key, fn)
+		m.opts.Reporter.OnComplete(key, m.active.Load(), err)
+		return result, err
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return v.(T), nil
+}
+
+func (m *Manager[T]) fetchWithRetry(ctx context.Context, key string, fn func(ctx context.Context) (T, error)) (T, error) {
+	var lastErr error
+	for attempt := 0; attempt <= m.opts.MaxRetries; attempt++ {
+		if attempt > 0 {
+			m.opts.Reporter.OnRetry(key, attempt, lastErr)
+			delay := backoffWithJitter(m.opts.BaseBackoff, m.opts.MaxBackoff, attempt)
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				var zero T
+				return zero, ctx.Err()
+			}
+		}
+
+		result, err := fn(ctx)
+		if err == nil {
+			return result, nil
+		}
+		lastErr = err
+		if !m.opts.Retryable(err) {
+			break
+		}
+	}
+	var zero T
+	return zero, lastErr
+}
+
+// backoffWithJitter takes a delay that doubles with each attempt, capped at
+// max, and returns a random duration between half of it and all of it, to
+// avoid thundering-herd retries.
+func backoffWithJitter(base, max time.Duration, attempt int) time.Duration {
+	delay := base << (attempt - 1)
+	if delay <= 0 || delay > max {
+		delay = max
+	}
+	jitter := time.Duration(rand.Int63n(int64(delay)/2 + 1))
+	return delay/2 + jitter
+}
@@ -0,0 +1,145 @@
+package xfer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestManagerFetchDeduplicatesConcurrentCallers(t *testing.T) {
+	m := NewManager[string](Options{Concurrency: 4})
+
+	const callers = 10
+
+	var calls atomic.Int32
+	fn := func(ctx context.Context) (string, error) {
+		calls.Add(1)
+		// Hold the fetch in flight until every caller has entered Fetch, so
+		// all of them share this one call rather than starting a new one.
+		for m.queued.Load() < callers {
+			time.Sleep(time.Millisecond)
+		}
+		return "content", nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([]string, callers)
+	errs := make([]error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = m.Fetch(context.Background(), "same-key", fn)
+		}(i)
+	}
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("fn was called %d times, want 1 (callers sharing a key should be deduplicated)", got)
+	}
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("caller %d: unexpected error: %v", i, err)
+		}
+		if results[i] != "content" {
+			t.Fatalf("caller %d: result = %q, want %q", i, results[i], "content")
+		}
+	}
+}
+
+type transientError struct{}
+
+func (transientError) Error() string { return "transient" }
+
+func TestManagerFetchRetriesTransientErrors(t *testing.T) {
+	m := NewManager[string](Options{
+		Concurrency: 1,
+		MaxRetries:  3,
+		BaseBackoff: 1,
+		MaxBackoff:  1,
+		Retryable: func(err error) bool {
+			var te transientError
+			return errors.As(err, &te)
+		},
+	})
+
+	var attempts atomic.Int32
+	fn := func(ctx context.Context) (string, error) {
+		if attempts.Add(1) <= 2 {
+			return "", transientError{}
+		}
+		return "ok", nil
+	}
+
+	got, err := m.Fetch(context.Background(), "key", fn)
+	if err != nil {
+		t.Fatalf("Fetch: unexpected error: %v", err)
+	}
+	if got != "ok" {
+		t.Fatalf("Fetch: result = %q, want %q", got, "ok")
+	}
+	if n := attempts.Load(); n != 3 {
+		t.Fatalf("fn was attempted %d times, want 3 (2 failures + 1 success)", n)
+	}
+}
+
+func TestManagerFetchGivesUpOnNonRetryableError(t *testing.T) {
+	m := NewManager[string](Options{
+		Concurrency: 1,
+		MaxRetries:  3,
+		BaseBackoff: 1,
+		MaxBackoff:  1,
+		Retryable:   func(error) bool { return false },
+	})
+
+	var attempts atomic.Int32
+	fn := func(ctx context.Context) (string, error) {
+		attempts.Add(1)
+		return "", errors.New("permanent")
+	}
+
+	if _, err := m.Fetch(context.Background(), "key", fn); err == nil {
+		t.Fatalf("Fetch: expected an error")
+	}
+	if n := attempts.Load(); n != 1 {
+		t.Fatalf("fn was attempted %d times, want 1 (non-retryable errors shouldn't be retried)", n)
+	}
+}
+
+func TestManagerFetchQueuedCounterReturnsToZero(t *testing.T) {
+	m := NewManager[string](Options{Concurrency: 2})
+
+	fn := func(ctx context.Context) (string, error) {
+		return "v", nil
+	}
+
+	const callers = 20
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// Half the callers share one key, half share another, so both
+			// singleflight leaders and followers exercise the queued
+			// counter's increment/decrement pairing.
+			key := "a"
+			if i%2 == 0 {
+				key = "b"
+			}
+			if _, err := m.Fetch(context.Background(), key, fn); err != nil {
+				t.Errorf("Fetch: unexpected error: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if got := m.queued.Load(); got != 0 {
+		t.Fatalf("queued = %d after all fetches completed, want 0 (leaked counter)", got)
+	}
+	if got := m.active.Load(); got != 0 {
+		t.Fatalf("active = %d after all fetches completed, want 0", got)
+	}
+}
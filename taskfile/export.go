@@ -0,0 +1,170 @@
+package taskfile
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/go-task/task/v3/internal/filepathext"
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+// GraphFormat is an output format supported by Reader.ExportGraph.
+type GraphFormat string
+
+const (
+	GraphFormatDOT     GraphFormat = "dot"
+	GraphFormatJSON    GraphFormat = "json"
+	GraphFormatMermaid GraphFormat = "mermaid"
+)
+
+// graphExport is the intermediate, format-agnostic shape ExportGraph renders
+// from the Reader's resolved include DAG.
+type graphExport struct {
+	Vertices []graphVertex `json:"vertices"`
+	Edges    []graphEdge   `json:"edges"`
+}
+
+type graphVertex struct {
+	URI string `json:"uri"`
+}
+
+type graphEdge struct {
+	From      string         `json:"from"`
+	To        string         `json:"to"`
+	Namespace string         `json:"namespace,omitempty"`
+	Aliases   []string       `json:"aliases,omitempty"`
+	Vars      map[string]any `json:"vars,omitempty"`
+	Optional  bool           `json:"optional,omitempty"`
+	Internal  bool           `json:"internal,omitempty"`
+	Weight    int            `json:"weight"`
+}
+
+// ExportGraph renders the Reader's resolved include DAG to w in the given
+// format, labeling vertices with their resolved URI (relative to the working
+// directory when possible) and edges with the namespace/aliases/vars and
+// optional/internal flags that include() attached to them via
+// graph.EdgeData, plus a weight equal to the number of include statements
+// collapsed into that edge.
+//
+// TODO: not yet wired up to a `--graph` CLI flag; there is no cmd/ package
+// in this tree to wire it into. Exposing it on the task binary is tracked
+// separately.
+func (r *Reader) ExportGraph(w io.Writer, format GraphFormat) error {
+	export, err := r.buildGraphExport()
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case GraphFormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(export)
+	case GraphFormatDOT:
+		return writeGraphDOT(w, export)
+	case GraphFormatMermaid:
+		return writeGraphMermaid(w, export)
+	default:
+		return fmt.Errorf("task: unsupported graph export format %q", format)
+	}
+}
+
+func (r *Reader) buildGraphExport() (*graphExport, error) {
+	adjacency, err := r.graph.AdjacencyMap()
+	if err != nil {
+		return nil, err
+	}
+
+	uris := make([]string, 0, len(adjacency))
+	for uri := range adjacency {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+
+	export := &graphExport{}
+	for _, uri := range uris {
+		export.Vertices = append(export.Vertices, graphVertex{URI: filepathext.TryAbsToRel(uri)})
+
+		targets := make([]string, 0, len(adjacency[uri]))
+		for target := range adjacency[uri] {
+			targets = append(targets, target)
+		}
+		sort.Strings(targets)
+
+		for _, target := range targets {
+			includes, _ := adjacency[uri][target].Properties.Data.([]*ast.Include)
+			edge := graphEdge{
+				From:   filepathext.TryAbsToRel(uri),
+				To:     filepathext.TryAbsToRel(target),
+				Weight: len(includes),
+			}
+			for _, include := range includes {
+				if edge.Namespace == "" {
+					edge.Namespace = include.Namespace
+				}
+				edge.Aliases = append(edge.Aliases, include.Aliases...)
+				if include.Vars != nil {
+					if edge.Vars == nil {
+						edge.Vars = make(map[string]any)
+					}
+					for k, v := range include.Vars.ToCacheMap() {
+						edge.Vars[k] = v
+					}
+				}
+				edge.Optional = edge.Optional || include.Optional
+				edge.Internal = edge.Internal || include.Internal
+			}
+			export.Edges = append(export.Edges, edge)
+		}
+	}
+	return export, nil
+}
+
+func edgeLabel(e graphEdge) string {
+	if len(e.Aliases) == 0 {
+		return e.Namespace
+	}
+	return fmt.Sprintf("%s (%s)", e.Namespace, strings.Join(e.Aliases, ", "))
+}
+
+func writeGraphDOT(w io.Writer, export *graphExport) error {
+	if _, err := fmt.Fprintln(w, "digraph {"); err != nil {
+		return err
+	}
+	for _, v := range export.Vertices {
+		if _, err := fmt.Fprintf(w, "\t%q;\n", v.URI); err != nil {
+			return err
+		}
+	}
+	for _, e := range export.Edges {
+		if _, err := fmt.Fprintf(w, "\t%q -> %q [label=%q, weight=%d];\n", e.From, e.To, edgeLabel(e), e.Weight); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintln(w, "}")
+	return err
+}
+
+func writeGraphMermaid(w io.Writer, export *graphExport) error {
+	if _, err := fmt.Fprintln(w, "graph TD"); err != nil {
+		return err
+	}
+
+	ids := make(map[string]string, len(export.Vertices))
+	for i, v := range export.Vertices {
+		id := fmt.Sprintf("n%d", i)
+		ids[v.URI] = id
+		if _, err := fmt.Fprintf(w, "\t%s[%q]\n", id, v.URI); err != nil {
+			return err
+		}
+	}
+	for _, e := range export.Edges {
+		if _, err := fmt.Fprintf(w, "\t%s -->|%q| %s\n", ids[e.From], edgeLabel(e), ids[e.To]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package taskfile
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+// threeNodeExport is the graphExport buildGraphExport would produce for a
+// root Taskfile that includes two namespaces, one of them aliased, optional,
+// and carrying vars. It's used to pin down the DOT/JSON/Mermaid renderers
+// against a shape of data close to the one a regression in buildGraphExport
+// once silently dropped (784d97e dropped include vars from exported edges).
+func threeNodeExport() *graphExport {
+	return &graphExport{
+		Vertices: []graphVertex{
+			{URI: "Taskfile.yml"},
+			{URI: "deploy/Taskfile.yml"},
+			{URI: "lib/Taskfile.yml"},
+		},
+		Edges: []graphEdge{
+			{
+				From:      "Taskfile.yml",
+				To:        "deploy/Taskfile.yml",
+				Namespace: "deploy",
+				Aliases:   []string{"d"},
+				Vars:      map[string]any{"ENV": "production"},
+				Optional:  true,
+				Weight:    1,
+			},
+			{
+				From:      "Taskfile.yml",
+				To:        "lib/Taskfile.yml",
+				Namespace: "lib",
+				Internal:  true,
+				Weight:    2,
+			},
+		},
+	}
+}
+
+func TestWriteGraphDOT(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeGraphDOT(&buf, threeNodeExport()); err != nil {
+		t.Fatalf("writeGraphDOT: unexpected error: %v", err)
+	}
+
+	want := `digraph {
+	"Taskfile.yml";
+	"deploy/Taskfile.yml";
+	"lib/Taskfile.yml";
+	"Taskfile.yml" -> "deploy/Taskfile.yml" [label="deploy (d)", weight=1];
+	"Taskfile.yml" -> "lib/Taskfile.yml" [label="lib", weight=2];
+}
+`
+	if got := buf.String(); got != want {
+		t.Fatalf("writeGraphDOT output =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestWriteGraphMermaid(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeGraphMermaid(&buf, threeNodeExport()); err != nil {
+		t.Fatalf("writeGraphMermaid: unexpected error: %v", err)
+	}
+
+	want := `graph TD
+	n0["Taskfile.yml"]
+	n1["deploy/Taskfile.yml"]
+	n2["lib/Taskfile.yml"]
+	n0 -->|"deploy (d)"| n1
+	n0 -->|"lib"| n2
+`
+	if got := buf.String(); got != want {
+		t.Fatalf("writeGraphMermaid output =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestExportGraphJSONIncludesVarsAliasesAndFlags(t *testing.T) {
+	export := threeNodeExport()
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(export); err != nil {
+		t.Fatalf("Encode: unexpected error: %v", err)
+	}
+
+	var decoded graphExport
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Unmarshal: unexpected error: %v", err)
+	}
+
+	deployEdge := decoded.Edges[0]
+	if deployEdge.Vars["ENV"] != "production" {
+		t.Fatalf("deploy edge Vars[ENV] = %v, want %q (the JSON encoding must not drop include vars)", deployEdge.Vars["ENV"], "production")
+	}
+	if len(deployEdge.Aliases) != 1 || deployEdge.Aliases[0] != "d" {
+		t.Fatalf("deploy edge Aliases = %v, want [d]", deployEdge.Aliases)
+	}
+	if !deployEdge.Optional {
+		t.Fatalf("deploy edge Optional = false, want true")
+	}
+
+	libEdge := decoded.Edges[1]
+	if !libEdge.Internal {
+		t.Fatalf("lib edge Internal = false, want true")
+	}
+	if len(libEdge.Vars) != 0 {
+		t.Fatalf("lib edge Vars = %v, want empty (no vars were attached to this include)", libEdge.Vars)
+	}
+}
+
+func TestEdgeLabelWithoutAliases(t *testing.T) {
+	e := graphEdge{Namespace: "lib"}
+	if got, want := edgeLabel(e), "lib"; got != want {
+		t.Fatalf("edgeLabel = %q, want %q", got, want)
+	}
+}
+
+func TestEdgeLabelWithAliases(t *testing.T) {
+	e := graphEdge{Namespace: "deploy", Aliases: []string{"d", "dep"}}
+	if got, want := edgeLabel(e), "deploy (d, dep)"; got != want {
+		t.Fatalf("edgeLabel = %q, want %q", got, want)
+	}
+}
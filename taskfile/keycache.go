@@ -0,0 +1,50 @@
+package taskfile
+
+import (
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultKeyCacheName is the file Reader uses to remember which signing key
+// fingerprint was last seen for each signed remote include, so that a key
+// rotation can be flagged the same way a changed checksum is.
+const DefaultKeyCacheName = "signatures.lock.yaml"
+
+// keyCache persists the fingerprint of the signing key most recently seen
+// for each signed remote include.
+type keyCache struct {
+	Fingerprints map[string]string `yaml:"fingerprints"`
+
+	path string
+}
+
+func readKeyCache(path string) (*keyCache, error) {
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &keyCache{Fingerprints: make(map[string]string), path: path}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	kc := &keyCache{path: path}
+	if err := yaml.Unmarshal(b, kc); err != nil {
+		return nil, err
+	}
+	if kc.Fingerprints == nil {
+		kc.Fingerprints = make(map[string]string)
+	}
+	return kc, nil
+}
+
+func (kc *keyCache) write() error {
+	if err := os.MkdirAll(filepath.Dir(kc.path), 0o755); err != nil {
+		return err
+	}
+	b, err := yaml.Marshal(kc)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(kc.path, b, 0o644)
+}
@@ -0,0 +1,166 @@
+package taskfile
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/go-task/task/v3/errors"
+)
+
+// DefaultLockfileName is the name Task looks for next to the entrypoint
+// Taskfile when no explicit lockfile path is given.
+const DefaultLockfileName = "Taskfile.lock.yaml"
+
+// LockfileAlgorithm identifies the hash algorithm used to compute a
+// LockfileEntry's checksum.
+type LockfileAlgorithm string
+
+const (
+	LockfileAlgorithmSHA256 LockfileAlgorithm = "sha256"
+	LockfileAlgorithmSHA512 LockfileAlgorithm = "sha512"
+)
+
+// LockfileEntry pins a single remote include to an expected checksum.
+type LockfileEntry struct {
+	Checksum  string            `yaml:"checksum"`
+	Algorithm LockfileAlgorithm `yaml:"algorithm"`
+	Tags      []string          `yaml:"tags,omitempty"`
+}
+
+// Lockfile is the parsed representation of a Taskfile.lock.yaml file. It maps
+// the location of a remote include to the entry that pins it.
+type Lockfile struct {
+	Version int                       `yaml:"version"`
+	Entries map[string]*LockfileEntry `yaml:"includes"`
+
+	path string
+
+	// mu guards Entries. Reader.include fans out one goroutine per sibling
+	// include, and each can reach Update/Verify concurrently for the same
+	// Lockfile.
+	mu sync.Mutex
+}
+
+// NewLockfile creates an empty lockfile that will be written to path.
+func NewLockfile(path string) *Lockfile {
+	return &Lockfile{
+		Version: 1,
+		Entries: make(map[string]*LockfileEntry),
+		path:    path,
+	}
+}
+
+// ReadLockfile reads and parses the lockfile at path. If the file does not
+// exist, an empty lockfile targeting that path is returned so callers can
+// populate it via Update and Write.
+func ReadLockfile(path string) (*Lockfile, error) {
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return NewLockfile(path), nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	lockfile := &Lockfile{path: path}
+	if err := yaml.Unmarshal(b, lockfile); err != nil {
+		return nil, err
+	}
+	if lockfile.Entries == nil {
+		lockfile.Entries = make(map[string]*LockfileEntry)
+	}
+	return lockfile, nil
+}
+
+// Write persists the lockfile to its path, creating parent directories as
+// needed.
+func (l *Lockfile) Write() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o755); err != nil {
+		return err
+	}
+	b, err := yaml.Marshal(l)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(l.path, b, 0o644)
+}
+
+// Update records or overwrites the entry for uri using the given algorithm.
+func (l *Lockfile) Update(uri string, algorithm LockfileAlgorithm, checksum string, tags []string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.Entries[uri] = &LockfileEntry{
+		Checksum:  checksum,
+		Algorithm: algorithm,
+		Tags:      tags,
+	}
+}
+
+// ExistingAlgorithm returns the hash algorithm already pinned for uri, if
+// any, so callers regenerating an entry can keep using it instead of
+// silently switching algorithms on every entry the file happens to touch.
+func (l *Lockfile) ExistingAlgorithm(uri string) (LockfileAlgorithm, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry, ok := l.Entries[uri]
+	if !ok {
+		return "", false
+	}
+	return entry.Algorithm, true
+}
+
+// Verify checks that the checksum computed for uri's content matches the
+// pinned entry. If frozen is true, a missing entry is treated as an error
+// instead of being silently accepted.
+func (l *Lockfile) Verify(uri string, content []byte, frozen bool) error {
+	l.mu.Lock()
+	entry, ok := l.Entries[uri]
+	l.mu.Unlock()
+	if !ok {
+		if frozen {
+			return &errors.TaskfileLockfileMissingEntryError{URI: uri}
+		}
+		return nil
+	}
+
+	actual, err := checksumWithAlgorithm(entry.Algorithm, content)
+	if err != nil {
+		return err
+	}
+	if actual != entry.Checksum {
+		return &errors.TaskfileLockfileMismatchError{
+			URI:              uri,
+			Algorithm:        string(entry.Algorithm),
+			ExpectedChecksum: entry.Checksum,
+			ActualChecksum:   actual,
+		}
+	}
+	return nil
+}
+
+// checksumWithAlgorithm hashes content with the given algorithm, defaulting
+// to sha256 when algorithm is empty so older lockfiles without an explicit
+// algorithm field keep working.
+func checksumWithAlgorithm(algorithm LockfileAlgorithm, content []byte) (string, error) {
+	switch algorithm {
+	case "", LockfileAlgorithmSHA256:
+		sum := sha256.Sum256(content)
+		return hex.EncodeToString(sum[:]), nil
+	case LockfileAlgorithmSHA512:
+		sum := sha512.Sum512(content)
+		return hex.EncodeToString(sum[:]), nil
+	default:
+		return "", fmt.Errorf("task: unsupported lockfile hash algorithm %q", algorithm)
+	}
+}
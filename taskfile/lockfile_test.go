@@ -0,0 +1,66 @@
+package taskfile
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/go-task/task/v3/errors"
+)
+
+func TestLockfileVerifyMismatch(t *testing.T) {
+	l := NewLockfile(filepath.Join(t.TempDir(), "Taskfile.lock.yaml"))
+	l.Update("https://example.com/Taskfile.yml", LockfileAlgorithmSHA256, "deadbeef", nil)
+
+	err := l.Verify("https://example.com/Taskfile.yml", []byte("version: '3'\n"), false)
+	var mismatch *errors.TaskfileLockfileMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("Verify: got %v, want a *TaskfileLockfileMismatchError", err)
+	}
+}
+
+func TestLockfileVerifyMissingEntryUnfrozen(t *testing.T) {
+	l := NewLockfile(filepath.Join(t.TempDir(), "Taskfile.lock.yaml"))
+
+	if err := l.Verify("https://example.com/Taskfile.yml", []byte("version: '3'\n"), false); err != nil {
+		t.Fatalf("Verify: unexpected error for an unpinned include when not frozen: %v", err)
+	}
+}
+
+func TestLockfileVerifyMissingEntryFrozen(t *testing.T) {
+	l := NewLockfile(filepath.Join(t.TempDir(), "Taskfile.lock.yaml"))
+
+	err := l.Verify("https://example.com/Taskfile.yml", []byte("version: '3'\n"), true)
+	var missing *errors.TaskfileLockfileMissingEntryError
+	if !errors.As(err, &missing) {
+		t.Fatalf("Verify: got %v, want a *TaskfileLockfileMissingEntryError", err)
+	}
+}
+
+func TestLockfileVerifyMatchingChecksum(t *testing.T) {
+	l := NewLockfile(filepath.Join(t.TempDir(), "Taskfile.lock.yaml"))
+	content := []byte("version: '3'\n")
+
+	checksum, err := checksumWithAlgorithm(LockfileAlgorithmSHA512, content)
+	if err != nil {
+		t.Fatalf("checksumWithAlgorithm: %v", err)
+	}
+	l.Update("https://example.com/Taskfile.yml", LockfileAlgorithmSHA512, checksum, nil)
+
+	if err := l.Verify("https://example.com/Taskfile.yml", content, true); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+}
+
+func TestLockfileExistingAlgorithmSurvivesMigration(t *testing.T) {
+	l := NewLockfile(filepath.Join(t.TempDir(), "Taskfile.lock.yaml"))
+	l.Update("https://example.com/Taskfile.yml", LockfileAlgorithmSHA512, "deadbeef", nil)
+
+	algorithm, ok := l.ExistingAlgorithm("https://example.com/Taskfile.yml")
+	if !ok || algorithm != LockfileAlgorithmSHA512 {
+		t.Fatalf("ExistingAlgorithm = (%v, %v), want (%v, true)", algorithm, ok, LockfileAlgorithmSHA512)
+	}
+
+	if _, ok := l.ExistingAlgorithm("https://example.com/other.yml"); ok {
+		t.Fatalf("ExistingAlgorithm: expected ok=false for an unpinned uri")
+	}
+}
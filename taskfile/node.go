@@ -0,0 +1,308 @@
+package taskfile
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-task/task/v3/errors"
+	"github.com/go-task/task/v3/internal/logger"
+)
+
+// Source is a Taskfile's raw content as read from a Node, along with the
+// directory it should be considered to live in for resolving relative paths.
+type Source struct {
+	FileContent   []byte
+	FileDirectory string
+	// Checksum, when non-empty, is a content-identifying digest the
+	// transport already had on hand (e.g. an S3 object's ETag), used in
+	// place of hashing FileContent for the TOFU cache and parsed-Taskfile
+	// cache keys. It is never substituted for a lockfile-pinned checksum,
+	// since a lockfile entry names a specific hash algorithm.
+	Checksum string
+}
+
+// Node is a location a Taskfile can be read from: a local file, an HTTP(S)
+// URL, or (via a SchemeRegistry) any other scheme a NodeFactory has been
+// registered for.
+type Node interface {
+	Location() string
+	ResolveEntrypoint(entrypoint string) (string, error)
+	ResolveDir(dir string) (string, error)
+	Read() (*Source, error)
+}
+
+type nodeOptions struct {
+	parent  Node
+	schemes *SchemeRegistry
+}
+
+// NodeOption configures optional Node behaviour passed to NewNode.
+type NodeOption func(*nodeOptions)
+
+// WithParent associates a Node with the Node that included it, so relative
+// entrypoints and directories can be resolved against it.
+func WithParent(parent Node) NodeOption {
+	return func(o *nodeOptions) {
+		o.parent = parent
+	}
+}
+
+// WithSchemeRegistry overrides which SchemeRegistry NewNode consults for
+// non-builtin schemes. A nil registry falls back to the built-in defaults.
+func WithSchemeRegistry(schemes *SchemeRegistry) NodeOption {
+	return func(o *nodeOptions) {
+		o.schemes = schemes
+	}
+}
+
+// NewNode builds the Node for entrypoint. Local paths (no scheme) become a
+// FileNode, "http"/"https" become a RemoteNode backed by a plain HTTP
+// transport, and any other scheme is dispatched to the NodeFactory
+// registered for it in the given SchemeRegistry, or in the built-in default
+// registry when none was given. A registry that lacks the scheme is an error;
+// the default registry is not consulted in that case.
+func NewNode(
+	l *logger.Logger,
+	entrypoint string,
+	dir string,
+	insecure bool,
+	timeout time.Duration,
+	opts ...NodeOption,
+) (Node, error) {
+	var o nodeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	switch scheme := schemeOf(entrypoint); scheme {
+	case "", "file":
+		return NewFileNode(entrypoint, dir, o.parent)
+	case "http", "https":
+		return NewRemoteNode(l, entrypoint, dir, insecure, timeout, httpTransport{}, o.parent)
+	default:
+		schemes := o.schemes
+		if schemes == nil {
+			schemes = defaultSchemeRegistry
+		}
+		factory, ok := schemes.lookup(scheme)
+		if !ok {
+			return nil, fmt.Errorf("task: unsupported Taskfile scheme %q", scheme)
+		}
+		return factory(l, entrypoint, dir, insecure, timeout, o.parent)
+	}
+}
+
+func schemeOf(entrypoint string) string {
+	u, err := url.Parse(entrypoint)
+	if err != nil {
+		return ""
+	}
+	return u.Scheme
+}
+
+// FileNode reads a Taskfile from the local filesystem.
+type FileNode struct {
+	Entrypoint string
+	Dir        string
+	parent     Node
+}
+
+func NewFileNode(entrypoint, dir string, parent Node) (*FileNode, error) {
+	return &FileNode{Entrypoint: entrypoint, Dir: dir, parent: parent}, nil
+}
+
+func (n *FileNode) Location() string { return n.Entrypoint }
+
+func (n *FileNode) ResolveEntrypoint(entrypoint string) (string, error) {
+	if filepath.IsAbs(entrypoint) {
+		return entrypoint, nil
+	}
+	return filepath.Join(filepath.Dir(n.Entrypoint), entrypoint), nil
+}
+
+func (n *FileNode) ResolveDir(dir string) (string, error) {
+	if dir == "" {
+		return filepath.Dir(n.Entrypoint), nil
+	}
+	if filepath.IsAbs(dir) {
+		return dir, nil
+	}
+	return filepath.Join(filepath.Dir(n.Entrypoint), dir), nil
+}
+
+func (n *FileNode) Read() (*Source, error) {
+	b, err := os.ReadFile(n.Entrypoint)
+	if err != nil {
+		return nil, err
+	}
+	return &Source{FileContent: b, FileDirectory: filepath.Dir(n.Entrypoint)}, nil
+}
+
+// remoteTransport performs the actual byte-fetch for a RemoteNode. Swapping
+// the transport is what lets git+https/s3/oci includes share RemoteNode's
+// existing cache/checksum/lockfile/signature handling in Reader.loadNode.
+// Implementations must respect ctx cancellation/deadline so a caller (e.g.
+// the transfer manager) can actually abort an in-flight fetch.
+type remoteTransport interface {
+	Fetch(ctx context.Context, url string, insecure bool, timeout time.Duration) ([]byte, error)
+}
+
+// checksumProvidingTransport is optionally implemented by a remoteTransport
+// that already has a content-identifying digest on hand after a successful
+// Fetch (e.g. an S3 object's ETag), so RemoteNode doesn't need to hash the
+// body itself for cache-key purposes.
+type checksumProvidingTransport interface {
+	Checksum() string
+}
+
+// RemoteNode reads a Taskfile fetched over the network. Its transport
+// determines how the bytes at URL are actually retrieved.
+type RemoteNode struct {
+	URL       string
+	Dir       string
+	Insecure  bool
+	Timeout   time.Duration
+	transport remoteTransport
+	parent    Node
+
+	content  []byte // memoized after the first successful fetch
+	checksum string // memoized alongside content, if transport provided one
+}
+
+func NewRemoteNode(
+	l *logger.Logger,
+	entrypoint, dir string,
+	insecure bool,
+	timeout time.Duration,
+	transport remoteTransport,
+	parent Node,
+) (*RemoteNode, error) {
+	return &RemoteNode{
+		URL:       entrypoint,
+		Dir:       dir,
+		Insecure:  insecure,
+		Timeout:   timeout,
+		transport: transport,
+		parent:    parent,
+	}, nil
+}
+
+func (n *RemoteNode) Location() string { return n.URL }
+
+func (n *RemoteNode) ResolveEntrypoint(entrypoint string) (string, error) {
+	base, err := url.Parse(n.URL)
+	if err != nil {
+		return "", err
+	}
+	ref, err := url.Parse(entrypoint)
+	if err != nil {
+		return "", err
+	}
+	return base.ResolveReference(ref).String(), nil
+}
+
+func (n *RemoteNode) ResolveDir(dir string) (string, error) {
+	return dir, nil
+}
+
+// Read fetches (or returns the memoized) content for n. It never observes
+// cancellation; callers that need that should use ReadContext directly.
+func (n *RemoteNode) Read() (*Source, error) {
+	return n.ReadContext(context.Background())
+}
+
+// ReadContext is like Read but threads ctx into the transport so an
+// in-flight fetch can actually be aborted on cancellation or deadline,
+// rather than only the caller's wait for a worker slot. The fetched content
+// is memoized, so a second call (e.g. Reader re-reading a node already
+// fetched by loadNode) doesn't hit the network again.
+func (n *RemoteNode) ReadContext(ctx context.Context) (*Source, error) {
+	if n.content == nil {
+		b, err := n.transport.Fetch(ctx, n.URL, n.Insecure, n.Timeout)
+		if err != nil {
+			return nil, err
+		}
+		n.content = b
+		if cp, ok := n.transport.(checksumProvidingTransport); ok {
+			n.checksum = cp.Checksum()
+		}
+	}
+	return &Source{FileContent: n.content, FileDirectory: n.Dir, Checksum: n.checksum}, nil
+}
+
+// httpTransport fetches a Taskfile over plain HTTP(S).
+type httpTransport struct{}
+
+func (httpTransport) Fetch(ctx context.Context, rawURL string, insecure bool, timeout time.Duration) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	client := &http.Client{}
+	if insecure {
+		client.Transport = &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		var netErr net.Error
+		if ok := asNetError(err, &netErr); ok && netErr.Timeout() {
+			return nil, errors.TaskfileNetworkTimeoutError{URI: rawURL, Timeout: timeout}
+		}
+		if ctx.Err() != nil {
+			return nil, errors.TaskfileNetworkTimeoutError{URI: rawURL, Timeout: timeout}
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError {
+		return nil, &errors.TaskfileNetworkServerError{URI: rawURL, StatusCode: resp.StatusCode}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("task: got HTTP %d fetching %q", resp.StatusCode, rawURL)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func asNetError(err error, target *net.Error) bool {
+	for err != nil {
+		if ne, ok := err.(net.Error); ok {
+			*target = ne
+			return true
+		}
+		u, ok := err.(interface{ Unwrap() error })
+		if !ok {
+			return false
+		}
+		err = u.Unwrap()
+	}
+	return false
+}
+
+// checksumSource returns a content-identifying digest for the TOFU checksum
+// cache and the parsed-Taskfile cache key: src.Checksum if the transport
+// already supplied one (e.g. an S3 ETag), otherwise a sha256 of the content
+// itself. It is never used for lockfile verification, which always hashes
+// with the algorithm named by the lockfile entry.
+func checksumSource(src Source) (string, error) {
+	if src.Checksum != "" {
+		return src.Checksum, nil
+	}
+	sum := sha256.Sum256(src.FileContent)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,141 @@
+package taskfile
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+
+	"github.com/go-task/task/v3/internal/logger"
+)
+
+// newGitNode builds the Node for a `git+https://host/repo.git//path/Taskfile.yml@ref`
+// (or `git+ssh://`) include: a shallow clone of the repo into a scratch
+// directory, checked out at ref, reading the Taskfile from the given subpath.
+func newGitNode(
+	l *logger.Logger,
+	entrypoint, dir string,
+	insecure bool,
+	timeout time.Duration,
+	parent Node,
+) (Node, error) {
+	repoURL, subpath, ref, err := parseGitEntrypoint(entrypoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewRemoteNode(l, entrypoint, dir, insecure, timeout, &gitTransport{
+		repoURL: repoURL,
+		subpath: subpath,
+		ref:     ref,
+	}, parent)
+}
+
+// parseGitEntrypoint splits a `git+<scheme>://host/repo.git//path/to/Taskfile.yml@ref`
+// entrypoint into the underlying repository URL, the path to the Taskfile
+// within it, and the optional ref to check out. The ref is taken from
+// whatever follows the LAST `@` in the subpath segment (i.e. after the
+// `//` separator), not by scanning the whole remainder for a `/`, so refs
+// like ordinary git-flow branch names (`release/1.2`, `feature/x`) are
+// parsed correctly instead of being mistaken for part of the subpath.
+func parseGitEntrypoint(entrypoint string) (repoURL, subpath, ref string, err error) {
+	rest, ok := strings.CutPrefix(entrypoint, "git+")
+	if !ok {
+		return "", "", "", fmt.Errorf("task: not a git+ entrypoint: %q", entrypoint)
+	}
+
+	schemeEnd := strings.Index(rest, "://")
+	if schemeEnd == -1 {
+		return "", "", "", fmt.Errorf("task: invalid git entrypoint %q", entrypoint)
+	}
+	sep := strings.Index(rest[schemeEnd+3:], "//")
+	if sep == -1 {
+		return "", "", "", fmt.Errorf("task: git entrypoint %q is missing a //path/to/Taskfile.yml subpath", entrypoint)
+	}
+	sep += schemeEnd + 3
+
+	repoURL = rest[:sep]
+	subpath = strings.TrimPrefix(rest[sep:], "//")
+
+	if at := strings.LastIndex(subpath, "@"); at != -1 {
+		ref = subpath[at+1:]
+		subpath = subpath[:at]
+	}
+
+	return repoURL, subpath, ref, nil
+}
+
+// gitTransport is a remoteTransport that resolves to a file inside a shallow
+// clone of a git repository.
+type gitTransport struct {
+	repoURL string
+	subpath string
+	ref     string
+}
+
+func (t *gitTransport) Fetch(ctx context.Context, _ string, _ bool, timeout time.Duration) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	tmpDir, err := os.MkdirTemp("", "task-git-include-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	cloneOpts := &git.CloneOptions{
+		URL:   t.repoURL,
+		Depth: 1,
+	}
+	if t.ref != "" {
+		cloneOpts.ReferenceName = plumbing.NewTagReferenceName(t.ref)
+	}
+
+	repo, err := git.PlainCloneContext(ctx, tmpDir, false, cloneOpts)
+	if err != nil && t.ref != "" {
+		// Not every ref is a tag; fall back to a full clone and check out
+		// whatever it resolves to (commit hash, or branch via its
+		// remote-tracking ref).
+		cloneOpts.ReferenceName = ""
+		cloneOpts.Depth = 0
+		repo, err = git.PlainCloneContext(ctx, tmpDir, false, cloneOpts)
+		if err == nil {
+			var w *git.Worktree
+			if w, err = repo.Worktree(); err == nil {
+				if err = w.Checkout(&git.CheckoutOptions{Hash: plumbing.NewHash(t.ref)}); err != nil {
+					err = w.Checkout(&git.CheckoutOptions{Branch: plumbing.NewRemoteReferenceName("origin", t.ref)})
+				}
+			}
+		}
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("task: cloning %q@%q: %w", t.repoURL, t.ref, ctx.Err())
+		}
+		return nil, fmt.Errorf("task: cloning %q@%q: %w", t.repoURL, t.ref, err)
+	}
+
+	path, err := safeJoin(tmpDir, t.subpath)
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(path)
+}
+
+// safeJoin joins subpath onto dir and rejects the result if it would escape
+// dir, so a subpath parsed out of an untrusted `git+https://...//subpath`
+// entrypoint (e.g. `../../../../etc/passwd`) can't be used to read files
+// outside the clone.
+func safeJoin(dir, subpath string) (string, error) {
+	joined := filepath.Join(dir, subpath)
+	rel, err := filepath.Rel(dir, joined)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("task: git subpath %q escapes the repository root", subpath)
+	}
+	return joined, nil
+}
@@ -0,0 +1,114 @@
+package taskfile
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestParseGitEntrypoint(t *testing.T) {
+	tests := []struct {
+		name        string
+		entrypoint  string
+		wantRepoURL string
+		wantSubpath string
+		wantRef     string
+		wantErr     bool
+	}{
+		{
+			name:        "https with ref",
+			entrypoint:  "git+https://github.com/go-task/task.git//Taskfile.yml@v3.0.0",
+			wantRepoURL: "https://github.com/go-task/task.git",
+			wantSubpath: "Taskfile.yml",
+			wantRef:     "v3.0.0",
+		},
+		{
+			name:        "ssh without ref",
+			entrypoint:  "git+ssh://git@github.com/go-task/task.git//Taskfile.yml",
+			wantRepoURL: "ssh://git@github.com/go-task/task.git",
+			wantSubpath: "Taskfile.yml",
+			wantRef:     "",
+		},
+		{
+			name:        "nested subpath",
+			entrypoint:  "git+https://github.com/go-task/task.git//deploy/Taskfile.yml@main",
+			wantRepoURL: "https://github.com/go-task/task.git",
+			wantSubpath: "deploy/Taskfile.yml",
+			wantRef:     "main",
+		},
+		{
+			name:        "ref containing a slash is not mistaken for part of the subpath",
+			entrypoint:  "git+https://github.com/go-task/task.git//Taskfile.yml@release/1.2",
+			wantRepoURL: "https://github.com/go-task/task.git",
+			wantSubpath: "Taskfile.yml",
+			wantRef:     "release/1.2",
+		},
+		{
+			name:       "missing git+ prefix",
+			entrypoint: "https://github.com/go-task/task.git//Taskfile.yml",
+			wantErr:    true,
+		},
+		{
+			name:       "missing subpath separator",
+			entrypoint: "git+https://github.com/go-task/task.git",
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoURL, subpath, ref, err := parseGitEntrypoint(tt.entrypoint)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseGitEntrypoint(%q): expected an error", tt.entrypoint)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseGitEntrypoint(%q): unexpected error: %v", tt.entrypoint, err)
+			}
+			if repoURL != tt.wantRepoURL {
+				t.Errorf("repoURL = %q, want %q", repoURL, tt.wantRepoURL)
+			}
+			if subpath != tt.wantSubpath {
+				t.Errorf("subpath = %q, want %q", subpath, tt.wantSubpath)
+			}
+			if ref != tt.wantRef {
+				t.Errorf("ref = %q, want %q", ref, tt.wantRef)
+			}
+		})
+	}
+}
+
+func TestSafeJoin(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		subpath string
+		wantErr bool
+	}{
+		{name: "plain file", subpath: "Taskfile.yml"},
+		{name: "nested path", subpath: "deploy/Taskfile.yml"},
+		{name: "escapes via ..", subpath: "../../../../etc/passwd", wantErr: true},
+		{name: "escapes via .. after a nested segment", subpath: "a/../../b", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := safeJoin(dir, tt.subpath)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("safeJoin(%q, %q): expected an error, got %q", dir, tt.subpath, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("safeJoin(%q, %q): unexpected error: %v", dir, tt.subpath, err)
+			}
+			want := filepath.Join(dir, tt.subpath)
+			if got != want {
+				t.Errorf("safeJoin(%q, %q) = %q, want %q", dir, tt.subpath, got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package taskfile
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/crane"
+
+	"github.com/go-task/task/v3/internal/logger"
+)
+
+// newOCINode builds the Node for an `oci://registry/repo:tag` include: a
+// single-layer OCI artifact whose layer content is the Taskfile itself.
+func newOCINode(
+	l *logger.Logger,
+	entrypoint, dir string,
+	insecure bool,
+	timeout time.Duration,
+	parent Node,
+) (Node, error) {
+	ref, ok := strings.CutPrefix(entrypoint, "oci://")
+	if !ok {
+		return nil, fmt.Errorf("task: not an oci:// entrypoint: %q", entrypoint)
+	}
+
+	return NewRemoteNode(l, entrypoint, dir, insecure, timeout, &ociTransport{ref: ref}, parent)
+}
+
+// ociTransport is a remoteTransport that pulls a Taskfile artifact from an
+// OCI registry. The artifact is expected to be a single-layer image whose
+// one layer's uncompressed content is the Taskfile.
+type ociTransport struct {
+	ref string
+}
+
+func (t *ociTransport) Fetch(ctx context.Context, _ string, insecure bool, timeout time.Duration) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	opts := []crane.Option{crane.WithContext(ctx)}
+	if insecure {
+		opts = append(opts, crane.Insecure)
+	}
+
+	img, err := crane.Pull(t.ref, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("task: pulling oci://%s: %w", t.ref, err)
+	}
+
+	layers, err := img.Layers()
+	if err != nil {
+		return nil, err
+	}
+	if len(layers) != 1 {
+		return nil, fmt.Errorf("task: oci://%s: expected a single-layer Taskfile artifact, got %d layers", t.ref, len(layers))
+	}
+
+	rc, err := layers[0].Uncompressed()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	content, err := io.ReadAll(rc)
+	if err != nil && ctx.Err() != nil {
+		return nil, fmt.Errorf("task: reading oci://%s: %w", t.ref, ctx.Err())
+	}
+	return content, err
+}
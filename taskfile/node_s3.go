@@ -0,0 +1,90 @@
+package taskfile
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/go-task/task/v3/internal/logger"
+)
+
+// newS3Node builds the Node for an `s3://bucket/key` include.
+func newS3Node(
+	l *logger.Logger,
+	entrypoint, dir string,
+	insecure bool,
+	timeout time.Duration,
+	parent Node,
+) (Node, error) {
+	bucket, key, err := parseS3Entrypoint(entrypoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewRemoteNode(l, entrypoint, dir, insecure, timeout, &s3Transport{
+		bucket: bucket,
+		key:    key,
+	}, parent)
+}
+
+func parseS3Entrypoint(entrypoint string) (bucket, key string, err error) {
+	rest, ok := strings.CutPrefix(entrypoint, "s3://")
+	if !ok {
+		return "", "", fmt.Errorf("task: not an s3:// entrypoint: %q", entrypoint)
+	}
+	bucket, key, ok = strings.Cut(rest, "/")
+	if !ok || key == "" {
+		return "", "", fmt.Errorf("task: s3 entrypoint %q is missing an object key", entrypoint)
+	}
+	return bucket, key, nil
+}
+
+// s3Transport is a remoteTransport that fetches an object from S3. It also
+// implements checksumProvidingTransport: after a successful Fetch, Checksum
+// returns the object's ETag so RemoteNode can use it as a content-identifying
+// digest for the TOFU/parsed-Taskfile caches instead of hashing the body,
+// the same way an HTTP include's body would otherwise be hashed.
+type s3Transport struct {
+	bucket string
+	key    string
+
+	etag string // captured from the last successful Fetch
+}
+
+func (t *s3Transport) Fetch(ctx context.Context, _ string, _ bool, timeout time.Duration) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("task: loading AWS config: %w", err)
+	}
+
+	client := s3.NewFromConfig(cfg)
+	out, err := client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(t.bucket),
+		Key:    aws.String(t.key),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("task: fetching s3://%s/%s: %w", t.bucket, t.key, err)
+	}
+	defer out.Body.Close()
+
+	if out.ETag != nil {
+		t.etag = strings.Trim(*out.ETag, `"`)
+	}
+
+	return io.ReadAll(out.Body)
+}
+
+// Checksum returns the ETag captured by the last successful Fetch, with its
+// surrounding literal quotes (as S3 returns them) stripped.
+func (t *s3Transport) Checksum() string {
+	return t.etag
+}
@@ -0,0 +1,75 @@
+package taskfile
+
+import "testing"
+
+func TestParseS3Entrypoint(t *testing.T) {
+	tests := []struct {
+		name       string
+		entrypoint string
+		wantBucket string
+		wantKey    string
+		wantErr    bool
+	}{
+		{
+			name:       "simple key",
+			entrypoint: "s3://my-bucket/Taskfile.yml",
+			wantBucket: "my-bucket",
+			wantKey:    "Taskfile.yml",
+		},
+		{
+			name:       "nested key",
+			entrypoint: "s3://my-bucket/taskfiles/deploy/Taskfile.yml",
+			wantBucket: "my-bucket",
+			wantKey:    "taskfiles/deploy/Taskfile.yml",
+		},
+		{
+			name:       "missing s3:// prefix",
+			entrypoint: "my-bucket/Taskfile.yml",
+			wantErr:    true,
+		},
+		{
+			name:       "missing key",
+			entrypoint: "s3://my-bucket",
+			wantErr:    true,
+		},
+		{
+			name:       "empty key after slash",
+			entrypoint: "s3://my-bucket/",
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bucket, key, err := parseS3Entrypoint(tt.entrypoint)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseS3Entrypoint(%q): expected an error", tt.entrypoint)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseS3Entrypoint(%q): unexpected error: %v", tt.entrypoint, err)
+			}
+			if bucket != tt.wantBucket {
+				t.Errorf("bucket = %q, want %q", bucket, tt.wantBucket)
+			}
+			if key != tt.wantKey {
+				t.Errorf("key = %q, want %q", key, tt.wantKey)
+			}
+		})
+	}
+}
+
+func TestS3TransportChecksumReflectsLastFetchETag(t *testing.T) {
+	tr := &s3Transport{bucket: "my-bucket", key: "Taskfile.yml"}
+
+	if got := tr.Checksum(); got != "" {
+		t.Fatalf("Checksum() before any Fetch = %q, want empty", got)
+	}
+
+	tr.etag = `"abc123"`
+	if got, want := tr.Checksum(), `"abc123"`; got != want {
+		t.Fatalf("Checksum() = %q, want %q (trimming happens in Fetch, not Checksum)", got, want)
+	}
+}
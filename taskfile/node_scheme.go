@@ -0,0 +1,58 @@
+package taskfile
+
+import (
+	"time"
+
+	"github.com/go-task/task/v3/internal/logger"
+)
+
+// NodeFactory builds the Node for an include whose entrypoint has a
+// non-builtin URL scheme (see SchemeRegistry).
+type NodeFactory func(
+	l *logger.Logger,
+	entrypoint, dir string,
+	insecure bool,
+	timeout time.Duration,
+	parent Node,
+) (Node, error)
+
+// SchemeRegistry maps include URL schemes to the NodeFactory that knows how
+// to fetch them. This is how Task supports includes beyond plain local files
+// and HTTP(S) URLs, e.g. `git+https://`, `s3://`, or `oci://`.
+type SchemeRegistry struct {
+	factories map[string]NodeFactory
+}
+
+// NewSchemeRegistry creates an empty SchemeRegistry. Use Register to add
+// factories, or start from DefaultSchemeRegistry to extend the built-ins.
+func NewSchemeRegistry() *SchemeRegistry {
+	return &SchemeRegistry{factories: make(map[string]NodeFactory)}
+}
+
+// DefaultSchemeRegistry returns a new SchemeRegistry seeded with Task's
+// built-in factories for `git+https://`, `git+ssh://`, `s3://`, and `oci://`
+// includes.
+func DefaultSchemeRegistry() *SchemeRegistry {
+	r := NewSchemeRegistry()
+	r.Register("git+https", newGitNode)
+	r.Register("git+ssh", newGitNode)
+	r.Register("s3", newS3Node)
+	r.Register("oci", newOCINode)
+	return r
+}
+
+// Register associates scheme with factory, overwriting any existing
+// registration (including a built-in one).
+func (s *SchemeRegistry) Register(scheme string, factory NodeFactory) {
+	s.factories[scheme] = factory
+}
+
+func (s *SchemeRegistry) lookup(scheme string) (NodeFactory, bool) {
+	if s == nil {
+		return nil, false
+	}
+	factory, ok := s.factories[scheme]
+	return factory, ok
+}
+
+var defaultSchemeRegistry = DefaultSchemeRegistry()
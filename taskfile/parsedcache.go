@@ -0,0 +1,51 @@
+package taskfile
+
+import (
+	"github.com/go-task/task/v3/internal/lru"
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+// DefaultParsedCacheSize is used by NewParsedCache callers that don't have an
+// opinion on how many parsed Taskfiles to keep resident.
+const DefaultParsedCacheSize = 128
+
+// ParsedCache memoizes parsed Taskfiles across Read calls and across sibling
+// includes that resolve to the same file at different positions in the
+// graph, keyed by URI and content checksum so a changed remote Taskfile
+// doesn't serve a stale parse. It can be shared across multiple Readers, e.g.
+// by a long-lived process that re-reads Taskfiles on every reload.
+type ParsedCache struct {
+	cache *lru.Cache[string, *ast.Taskfile]
+}
+
+// NewParsedCache creates a ParsedCache that keeps at most size parsed
+// Taskfiles resident, evicting the least recently used entry once that limit
+// is exceeded. A size <= 0 means unbounded.
+func NewParsedCache(size int) *ParsedCache {
+	return &ParsedCache{cache: lru.New[string, *ast.Taskfile](size)}
+}
+
+func parsedCacheKey(uri, checksum string) string {
+	return uri + "@" + checksum
+}
+
+// get returns the *ast.Taskfile stored for (uri, checksum), if any. The
+// returned pointer is shared with every other caller that hits the same
+// cache entry, including other Readers sharing this ParsedCache and
+// concurrent include() goroutines within one graph read — it is not copied.
+// Callers must treat it as read-only; readNode and its downstream graph code
+// only ever read from a parsed Taskfile after this point, and must continue
+// to do so for this cache to be safe to share.
+func (c *ParsedCache) get(uri, checksum string) (*ast.Taskfile, bool) {
+	if c == nil {
+		return nil, false
+	}
+	return c.cache.Get(parsedCacheKey(uri, checksum))
+}
+
+func (c *ParsedCache) put(uri, checksum string, tf *ast.Taskfile) {
+	if c == nil {
+		return
+	}
+	c.cache.Add(parsedCacheKey(uri, checksum), tf)
+}
@@ -0,0 +1,63 @@
+package taskfile
+
+import (
+	"testing"
+
+	"github.com/go-task/task/v3/taskfile/ast"
+)
+
+func TestParsedCacheGetMiss(t *testing.T) {
+	c := NewParsedCache(DefaultParsedCacheSize)
+	if _, ok := c.get("https://example.com/Taskfile.yml", "checksum"); ok {
+		t.Fatalf("get: got ok=true for an entry that was never put")
+	}
+}
+
+func TestParsedCachePutAndGet(t *testing.T) {
+	c := NewParsedCache(DefaultParsedCacheSize)
+	tf := &ast.Taskfile{}
+	c.put("https://example.com/Taskfile.yml", "checksum-a", tf)
+
+	got, ok := c.get("https://example.com/Taskfile.yml", "checksum-a")
+	if !ok {
+		t.Fatalf("get: ok = false, want true after put")
+	}
+	if got != tf {
+		t.Fatalf("get: returned a different *ast.Taskfile than was put")
+	}
+}
+
+func TestParsedCacheKeyedByChecksum(t *testing.T) {
+	c := NewParsedCache(DefaultParsedCacheSize)
+	c.put("https://example.com/Taskfile.yml", "checksum-a", &ast.Taskfile{})
+
+	if _, ok := c.get("https://example.com/Taskfile.yml", "checksum-b"); ok {
+		t.Fatalf("get: a changed checksum for the same URI must not hit the stale entry")
+	}
+}
+
+// TestParsedCacheSharesThePointerItWasGiven documents the current sharing
+// contract described on ParsedCache.get: two independent lookups that hit
+// the same (uri, checksum) entry get back the exact same *ast.Taskfile
+// instance, not independent copies. Anything that reads this value (e.g.
+// two concurrent Reader.Read calls sharing one ParsedCache) must not mutate
+// it in place.
+func TestParsedCacheSharesThePointerItWasGiven(t *testing.T) {
+	c := NewParsedCache(DefaultParsedCacheSize)
+	tf := &ast.Taskfile{}
+	c.put("https://example.com/Taskfile.yml", "checksum-a", tf)
+
+	first, _ := c.get("https://example.com/Taskfile.yml", "checksum-a")
+	second, _ := c.get("https://example.com/Taskfile.yml", "checksum-a")
+	if first != second {
+		t.Fatalf("get: two lookups of the same entry returned different pointers")
+	}
+}
+
+func TestParsedCacheNilReceiverIsANoop(t *testing.T) {
+	var c *ParsedCache
+	c.put("https://example.com/Taskfile.yml", "checksum-a", &ast.Taskfile{})
+	if _, ok := c.get("https://example.com/Taskfile.yml", "checksum-a"); ok {
+		t.Fatalf("get: a nil ParsedCache must always report a cache miss")
+	}
+}
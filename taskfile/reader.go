@@ -1,8 +1,10 @@
 package taskfile
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/dominikbraun/graph"
@@ -14,6 +16,7 @@ import (
 	"github.com/go-task/task/v3/internal/filepathext"
 	"github.com/go-task/task/v3/internal/logger"
 	"github.com/go-task/task/v3/internal/templater"
+	"github.com/go-task/task/v3/internal/xfer"
 	"github.com/go-task/task/v3/taskfile/ast"
 )
 
@@ -29,14 +32,152 @@ Continue?`
 // A Reader will recursively read Taskfiles from a given source using a directed
 // acyclic graph (DAG).
 type Reader struct {
-	graph    *ast.TaskfileGraph
-	node     Node
-	insecure bool
-	download bool
-	offline  bool
-	timeout  time.Duration
-	tempDir  string
-	logger   *logger.Logger
+	graph             *ast.TaskfileGraph
+	node              Node
+	insecure          bool
+	download          bool
+	offline           bool
+	timeout           time.Duration
+	tempDir           string
+	logger            *logger.Logger
+	lockfile          *Lockfile
+	frozenLockfile    bool
+	updateLockfile    bool
+	lockfileAlgorithm LockfileAlgorithm
+
+	transferConcurrency int
+	transfers           *xfer.Manager[*Source]
+
+	parsedCache *ParsedCache
+
+	keyring       Keyring
+	requireSigned bool
+	// keyCacheMu serializes checkKeyRotation's read-modify-write of the key
+	// cache file, since Reader.include fans out one goroutine per sibling
+	// include.
+	keyCacheMu sync.Mutex
+
+	schemes *SchemeRegistry
+}
+
+// ReaderOption configures optional Reader behaviour that most callers don't
+// need to set explicitly.
+type ReaderOption func(*Reader)
+
+// WithLockfile pins remote includes read by the Reader to the checksums
+// recorded in lockfile, matching pinned entries exactly and erroring out on
+// any mismatch instead of prompting.
+func WithLockfile(lockfile *Lockfile) ReaderOption {
+	return func(r *Reader) {
+		r.lockfile = lockfile
+	}
+}
+
+// WithFrozenLockfile requires every remote include to already have an entry
+// in the lockfile, mirroring the `--frozen-lockfile` semantics used by
+// package manager lockfiles in CI.
+//
+// TODO: not yet wired up to a `--frozen-lockfile` CLI flag or a
+// lockfile-update subcommand; there is no cmd/ package in this tree to wire
+// it into. Exposing it on the task binary is tracked separately.
+func WithFrozenLockfile(frozen bool) ReaderOption {
+	return func(r *Reader) {
+		r.frozenLockfile = frozen
+	}
+}
+
+// WithLockfileUpdate puts the Reader into lockfile-update mode: instead of
+// verifying remote includes against the lockfile, it (re)writes an entry for
+// each one it fetches. Reader.WriteLockfile must be called once Read has
+// finished to persist the changes.
+func WithLockfileUpdate(update bool) ReaderOption {
+	return func(r *Reader) {
+		r.updateLockfile = update
+	}
+}
+
+// WithLockfileAlgorithm sets the hash algorithm used when WithLockfileUpdate
+// (re)writes an entry that isn't already pinned. An entry that already has
+// an algorithm keeps it, so switching this doesn't silently migrate every
+// existing entry in the file; migrating one deliberately still works, since
+// removing its entry (or bumping algorithm) before an update run regenerates
+// it with the new algorithm. Defaults to LockfileAlgorithmSHA256.
+func WithLockfileAlgorithm(algorithm LockfileAlgorithm) ReaderOption {
+	return func(r *Reader) {
+		r.lockfileAlgorithm = algorithm
+	}
+}
+
+// WriteLockfile persists the Reader's lockfile, if one was configured with
+// WithLockfile. It is a no-op otherwise.
+func (r *Reader) WriteLockfile() error {
+	if r.lockfile == nil {
+		return nil
+	}
+	return r.lockfile.Write()
+}
+
+// WithSchemeRegistry lets includes use schemes beyond plain local files and
+// HTTP(S) URLs (e.g. `git+https://`, `s3://`, `oci://`), by consulting
+// registry's NodeFactory for any scheme it doesn't handle natively.
+func WithSchemeRegistry(registry *SchemeRegistry) ReaderOption {
+	return func(r *Reader) {
+		r.schemes = registry
+	}
+}
+
+// WithTransferConcurrency sets the maximum number of remote includes the
+// Reader will fetch at once. Defaults to 5.
+func WithTransferConcurrency(n int) ReaderOption {
+	return func(r *Reader) {
+		r.transferConcurrency = n
+	}
+}
+
+// WithParsedCache shares a ParsedCache between this Reader and any other
+// Reader it was given to, so identical Taskfiles (by URI and content
+// checksum) are only parsed once.
+func WithParsedCache(cache *ParsedCache) ReaderOption {
+	return func(r *Reader) {
+		r.parsedCache = cache
+	}
+}
+
+// transferReporter adapts the xfer.Reporter callbacks used by Reader's
+// transfer manager onto the Reader's logger.
+type transferReporter struct {
+	logger *logger.Logger
+}
+
+func (t *transferReporter) OnQueued(key string, queued int64) {
+	t.logger.VerboseOutf(logger.Magenta, "task: [%s] Queued for download (%d queued)\n", key, queued)
+}
+
+func (t *transferReporter) OnStart(key string, active, queued int64) {
+	t.logger.VerboseOutf(logger.Magenta, "task: [%s] Downloading (%d active, %d queued)\n", key, active, queued)
+}
+
+func (t *transferReporter) OnRetry(key string, attempt int, err error) {
+	t.logger.VerboseOutf(logger.Yellow, "task: [%s] Retrying download (attempt %d) after error: %v\n", key, attempt, err)
+}
+
+func (t *transferReporter) OnComplete(key string, active int64, err error) {
+	if err != nil {
+		t.logger.VerboseOutf(logger.Yellow, "task: [%s] Download failed: %v\n", key, err)
+		return
+	}
+	t.logger.VerboseOutf(logger.Magenta, "task: [%s] Download complete (%d active)\n", key, active)
+}
+
+// isRetryableFetchError reports whether err is a transient condition (a
+// network timeout, or a 429/5xx response) worth retrying.
+func isRetryableFetchError(err error) bool {
+	var te errors.TaskfileNetworkTimeoutError
+	if errors.As(err, &te) {
+		return true
+	}
+	var se *errors.TaskfileNetworkServerError
+	return errors.As(err, &se)
 }
 
 func NewReader(
@@ -47,8 +188,9 @@ func NewReader(
 	timeout time.Duration,
 	tempDir string,
 	logger *logger.Logger,
+	opts ...ReaderOption,
 ) *Reader {
-	return &Reader{
+	r := &Reader{
 		graph:    ast.NewTaskfileGraph(),
 		node:     node,
 		insecure: insecure,
@@ -58,18 +200,40 @@ func NewReader(
 		tempDir:  tempDir,
 		logger:   logger,
 	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if r.lockfileAlgorithm == "" {
+		r.lockfileAlgorithm = LockfileAlgorithmSHA256
+	}
+	r.transfers = xfer.NewManager[*Source](xfer.Options{
+		Concurrency: r.transferConcurrency,
+		MaxRetries:  3,
+		Retryable:   isRetryableFetchError,
+		Reporter:    &transferReporter{logger: logger},
+	})
+	return r
 }
 
+// Read recursively reads Taskfiles into a graph, using context.Background().
+// See ReadContext to pass a context that can cancel in-flight remote fetches.
 func (r *Reader) Read() (*ast.TaskfileGraph, error) {
+	return r.ReadContext(context.Background())
+}
+
+// ReadContext is like Read, but the given context is propagated down to the
+// Reader's transfer manager so that fetches of remote includes can be
+// cancelled.
+func (r *Reader) ReadContext(ctx context.Context) (*ast.TaskfileGraph, error) {
 	// Recursively loop through each Taskfile, adding vertices/edges to the graph
-	if err := r.include(r.node); err != nil {
+	if err := r.include(ctx, r.node); err != nil {
 		return nil, err
 	}
 
 	return r.graph, nil
 }
 
-func (r *Reader) include(node Node) error {
+func (r *Reader) include(ctx context.Context, node Node) error {
 	// Create a new vertex for the Taskfile
 	vertex := &ast.TaskfileVertex{
 		URI:      node.Location(),
@@ -87,7 +251,7 @@ func (r *Reader) include(node Node) error {
 
 	// Read and parse the Taskfile from the file and add it to the vertex
 	var err error
-	vertex.Taskfile, node, err = r.readNode(node)
+	vertex.Taskfile, node, err = r.readNode(ctx, node)
 	if err != nil {
 		return err
 	}
@@ -129,6 +293,7 @@ func (r *Reader) include(node Node) error {
 
 			includeNode, err := NewNode(r.logger, entrypoint, include.Dir, r.insecure, r.timeout,
 				WithParent(node),
+				WithSchemeRegistry(r.schemes),
 			)
 			if err != nil {
 				if include.Optional {
@@ -138,7 +303,7 @@ func (r *Reader) include(node Node) error {
 			}
 
 			// Recurse into the included Taskfile
-			if err := r.include(includeNode); err != nil {
+			if err := r.include(ctx, includeNode); err != nil {
 				return err
 			}
 
@@ -179,8 +344,8 @@ func (r *Reader) include(node Node) error {
 	return g.Wait()
 }
 
-func (r *Reader) readNode(node Node) (*ast.Taskfile, Node, error) {
-	node, err := r.loadNode(node)
+func (r *Reader) readNode(ctx context.Context, node Node) (*ast.Taskfile, Node, error) {
+	node, err := r.loadNode(ctx, node)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -190,6 +355,16 @@ func (r *Reader) readNode(node Node) (*ast.Taskfile, Node, error) {
 		return nil, nil, err
 	}
 
+	var checksum string
+	if r.parsedCache != nil {
+		if checksum, err = checksumSource(*src); err != nil {
+			return nil, nil, err
+		}
+		if tf, ok := r.parsedCache.get(node.Location(), checksum); ok {
+			return tf, node, nil
+		}
+	}
+
 	var tf ast.Taskfile
 	if err := yaml.Unmarshal(src.FileContent, &tf); err != nil {
 		// Decode the taskfile and add the file info the any errors
@@ -221,10 +396,14 @@ func (r *Reader) readNode(node Node) (*ast.Taskfile, Node, error) {
 		}
 	}
 
+	if r.parsedCache != nil {
+		r.parsedCache.put(node.Location(), checksum, &tf)
+	}
+
 	return &tf, node, nil
 }
 
-func (r *Reader) loadNode(n Node) (Node, error) {
+func (r *Reader) loadNode(ctx context.Context, n Node) (Node, error) {
 	remote, ok := n.(*RemoteNode)
 	if !ok {
 		return n, nil
@@ -243,12 +422,40 @@ func (r *Reader) loadNode(n Node) (Node, error) {
 		} else if err != nil {
 			return nil, err
 		}
+
+		// A cached copy still has to satisfy a pinned lockfile entry and a
+		// configured trusted signing key; the cache directory isn't itself a
+		// trust boundary.
+		if r.lockfile != nil || r.keyring != nil || r.requireSigned {
+			cachedRemote, ok := cached.(*RemoteNode)
+			if !ok {
+				return nil, fmt.Errorf("task: cached copy of %q is not a remote node", remote.Location())
+			}
+			cachedSrc, err := cachedRemote.ReadContext(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if err := r.verifySignature(ctx, cachedRemote, cachedSrc); err != nil {
+				return nil, err
+			}
+			if r.lockfile != nil {
+				if err := r.lockfile.Verify(remote.Location(), cachedSrc.FileContent, r.frozenLockfile); err != nil {
+					return nil, err
+				}
+			}
+		}
+
 		r.logger.VerboseOutf(logger.Magenta, "task: [%s] Fetched cached copy\n", remote.Location())
 
 		return cached, nil
 	}
 
-	src, err := remote.Read()
+	// Fetches are deduplicated and pooled by the transfer manager, so an
+	// include referenced from multiple places in the graph is only
+	// downloaded once, and retried with backoff on transient failures.
+	src, err := r.transfers.Fetch(ctx, remote.Location(), func(ctx context.Context) (*Source, error) {
+		return remote.ReadContext(ctx)
+	})
 
 	var te errors.TaskfileNetworkTimeoutError
 	if errors.As(err, &te) {
@@ -275,6 +482,36 @@ func (r *Reader) loadNode(n Node) (Node, error) {
 	}
 	r.logger.VerboseOutf(logger.Magenta, "task: [%s] Fetched remote copy\n", remote.Location())
 
+	if err := r.verifySignature(ctx, remote, src); err != nil {
+		return nil, err
+	}
+
+	// If a lockfile is configured, it replaces the TOFU checksum-cache/prompt
+	// flow below with a hard, non-interactive check (or, in update mode,
+	// (re)writes the pinned entry). An include with no entry is accepted
+	// without a prompt unless the lockfile is frozen.
+	if r.lockfile != nil {
+		if r.updateLockfile {
+			algorithm := r.lockfileAlgorithm
+			if existing, ok := r.lockfile.ExistingAlgorithm(remote.Location()); ok {
+				algorithm = existing
+			}
+			checksum, err := checksumWithAlgorithm(algorithm, src.FileContent)
+			if err != nil {
+				return nil, err
+			}
+			r.lockfile.Update(remote.Location(), algorithm, checksum, nil)
+		} else if err := r.lockfile.Verify(remote.Location(), src.FileContent, r.frozenLockfile); err != nil {
+			return nil, err
+		}
+
+		r.logger.VerboseOutf(logger.Magenta, "task: [%s] Caching downloaded file\n", remote.Location())
+		if remote, err = cache.write(*remote, *src); err != nil {
+			return nil, err
+		}
+		return remote, nil
+	}
+
 	// Get the checksums
 	cachedChecksum := cache.readChecksum(*remote)
 	checksum, err := checksumSource(*src)
@@ -0,0 +1,140 @@
+package taskfile
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// inlineSignaturePrefix marks a detached signature embedded as the first
+// line of a remote Taskfile, e.g. `# signature: <base64>`.
+const inlineSignaturePrefix = "# signature:"
+
+// SigningKey is a trusted Ed25519 public key used to verify remote
+// Taskfiles, along with the minisign key ID it was published with.
+type SigningKey struct {
+	KeyID     [8]byte
+	PublicKey ed25519.PublicKey
+}
+
+// Fingerprint returns a short, stable identifier for the key, used to detect
+// rotation: a trusted key changing for an include is treated the same way as
+// a changed checksum.
+func (k *SigningKey) Fingerprint() string {
+	return hex.EncodeToString(k.KeyID[:])
+}
+
+// ParseMinisignPublicKey parses a minisign public key file's contents (an
+// "untrusted comment:" line followed by a base64-encoded blob of
+// [2-byte algorithm][8-byte key ID][32-byte Ed25519 public key]).
+func ParseMinisignPublicKey(raw string) (*SigningKey, error) {
+	line := lastNonEmptyLine(raw)
+	blob, err := base64.StdEncoding.DecodeString(line)
+	if err != nil {
+		return nil, fmt.Errorf("task: invalid minisign public key: %w", err)
+	}
+	if len(blob) != 2+8+ed25519.PublicKeySize {
+		return nil, fmt.Errorf("task: invalid minisign public key: unexpected length %d", len(blob))
+	}
+	if string(blob[:2]) != "Ed" {
+		return nil, fmt.Errorf("task: unsupported minisign public key algorithm %q", blob[:2])
+	}
+
+	key := &SigningKey{PublicKey: ed25519.PublicKey(bytes.Clone(blob[10:]))}
+	copy(key.KeyID[:], blob[2:10])
+	return key, nil
+}
+
+// verifyDetachedSignature checks a decoded, normalized 64-byte Ed25519
+// signature against message using key.
+func verifyDetachedSignature(key *SigningKey, message, sig []byte) error {
+	if !ed25519.Verify(key.PublicKey, message, sig) {
+		return fmt.Errorf("task: signature verification failed for key %s", key.Fingerprint())
+	}
+	return nil
+}
+
+// normalizeEd25519Sig accepts either a bare 64-byte Ed25519 signature or a
+// minisign-style blob of [2-byte algorithm][8-byte key ID][64-byte
+// signature], and returns the bare 64-byte signature.
+func normalizeEd25519Sig(blob []byte) ([]byte, error) {
+	switch len(blob) {
+	case ed25519.SignatureSize:
+		return blob, nil
+	case 2 + 8 + ed25519.SignatureSize:
+		if string(blob[:2]) != "Ed" {
+			return nil, fmt.Errorf("task: unsupported minisign signature algorithm %q", blob[:2])
+		}
+		return blob[10:], nil
+	default:
+		return nil, fmt.Errorf("task: invalid signature length %d", len(blob))
+	}
+}
+
+// decodeSignatureFile parses a minisign detached signature file's contents
+// (an "untrusted comment:" line, then base64 of the signature blob,
+// optionally followed by a trusted-comment/global-signature footer which is
+// not verified here) into a normalized 64-byte Ed25519 signature.
+func decodeSignatureFile(raw []byte) ([]byte, error) {
+	lines := strings.Split(strings.TrimSpace(string(raw)), "\n")
+	var sigLine string
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "untrusted comment:") || strings.HasPrefix(line, "trusted comment:") {
+			continue
+		}
+		sigLine = line
+		break
+	}
+	if sigLine == "" {
+		return nil, fmt.Errorf("task: empty signature")
+	}
+
+	blob, err := base64.StdEncoding.DecodeString(sigLine)
+	if err != nil {
+		return nil, fmt.Errorf("task: invalid signature encoding: %w", err)
+	}
+	return normalizeEd25519Sig(blob)
+}
+
+// splitInlineSignature looks for a leading `# signature: <base64>` header in
+// content. If found, it returns the normalized signature bytes and the
+// remaining content with that header line removed, which is what the
+// signature was computed over.
+func splitInlineSignature(content []byte) (sig []byte, rest []byte, ok bool) {
+	nl := bytes.IndexByte(content, '\n')
+	var firstLine string
+	if nl == -1 {
+		firstLine = string(content)
+	} else {
+		firstLine = string(content[:nl])
+	}
+	firstLine = strings.TrimSpace(firstLine)
+	if !strings.HasPrefix(firstLine, inlineSignaturePrefix) {
+		return nil, nil, false
+	}
+
+	encoded := strings.TrimSpace(strings.TrimPrefix(firstLine, inlineSignaturePrefix))
+	blob, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || nl == -1 {
+		return nil, nil, false
+	}
+	normalized, err := normalizeEd25519Sig(blob)
+	if err != nil {
+		return nil, nil, false
+	}
+	return normalized, content[nl+1:], true
+}
+
+func lastNonEmptyLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		if line := strings.TrimSpace(lines[i]); line != "" {
+			return line
+		}
+	}
+	return ""
+}
@@ -0,0 +1,77 @@
+package taskfile
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"path/filepath"
+	"testing"
+)
+
+func newTestSigningKey(t *testing.T) (*SigningKey, ed25519.PrivateKey) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	key := &SigningKey{PublicKey: pub}
+	copy(key.KeyID[:], []byte("testkey1"))
+	return key, priv
+}
+
+func TestSplitInlineSignatureVerify(t *testing.T) {
+	key, priv := newTestSigningKey(t)
+
+	body := []byte("version: '3'\ntasks:\n  foo:\n    cmds:\n      - echo foo\n")
+	sig := ed25519.Sign(priv, body)
+
+	content := append([]byte(inlineSignaturePrefix+" "+base64.StdEncoding.EncodeToString(sig)+"\n"), body...)
+
+	gotSig, rest, ok := splitInlineSignature(content)
+	if !ok {
+		t.Fatalf("splitInlineSignature: expected ok=true")
+	}
+	if string(rest) != string(body) {
+		t.Fatalf("splitInlineSignature: rest = %q, want %q", rest, body)
+	}
+	if err := verifyDetachedSignature(key, rest, gotSig); err != nil {
+		t.Fatalf("verifyDetachedSignature: %v", err)
+	}
+}
+
+func TestVerifyDetachedSignatureRejectsTamperedMessage(t *testing.T) {
+	key, priv := newTestSigningKey(t)
+
+	message := []byte("version: '3'\n")
+	sig := ed25519.Sign(priv, message)
+
+	if err := verifyDetachedSignature(key, []byte("version: '4'\n"), sig); err == nil {
+		t.Fatalf("verifyDetachedSignature: expected an error for a tampered message")
+	}
+}
+
+func TestCheckKeyRotationRecordsAndReusesFingerprint(t *testing.T) {
+	dir := t.TempDir()
+	r := &Reader{tempDir: dir}
+
+	keyA := &SigningKey{}
+	copy(keyA.KeyID[:], []byte("aaaaaaaa"))
+
+	if err := r.checkKeyRotation("https://example.com/Taskfile.yml", keyA); err != nil {
+		t.Fatalf("first sighting of a key should not error: %v", err)
+	}
+
+	kc, err := readKeyCache(filepath.Join(dir, DefaultKeyCacheName))
+	if err != nil {
+		t.Fatalf("readKeyCache: %v", err)
+	}
+	if got := kc.Fingerprints["https://example.com/Taskfile.yml"]; got != keyA.Fingerprint() {
+		t.Fatalf("Fingerprints[uri] = %q, want %q", got, keyA.Fingerprint())
+	}
+
+	// r.logger is nil, so logger.Prompt would panic; checkKeyRotation only
+	// reaches it when the fingerprint changed, so pin the same key again to
+	// confirm the no-change path doesn't call it.
+	if err := r.checkKeyRotation("https://example.com/Taskfile.yml", keyA); err != nil {
+		t.Fatalf("re-checking the same key should not error: %v", err)
+	}
+}
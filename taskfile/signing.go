@@ -0,0 +1,169 @@
+package taskfile
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/go-task/task/v3/errors"
+	"github.com/go-task/task/v3/internal/logger"
+)
+
+const taskfileKeyRotatedPrompt = `The signing key for the remote Taskfile at %q has changed since you last used it!
+--- Make sure you trust the new key before continuing ---
+Continue?`
+
+// Keyring maps a remote include's location to the SigningKey that must sign
+// it.
+type Keyring map[string]*SigningKey
+
+// WithTrustedKey requires the remote include at uri to ship a detached
+// signature verifiable with key.
+func WithTrustedKey(uri string, key *SigningKey) ReaderOption {
+	return func(r *Reader) {
+		if r.keyring == nil {
+			r.keyring = make(Keyring)
+		}
+		r.keyring[uri] = key
+	}
+}
+
+// WithKeyring requires every remote include named in keyring to ship a
+// detached signature verifiable with its associated key.
+func WithKeyring(keyring Keyring) ReaderOption {
+	return func(r *Reader) {
+		r.keyring = keyring
+	}
+}
+
+// WithRequireSigned refuses to load any remote include that isn't covered by
+// the Reader's keyring and verified with a valid detached signature.
+//
+// TODO: not yet wired up to a `--require-signed` CLI flag; there is no cmd/
+// package in this tree to wire it into. Exposing it on the task binary is
+// tracked separately.
+func WithRequireSigned(require bool) ReaderOption {
+	return func(r *Reader) {
+		r.requireSigned = require
+	}
+}
+
+// verifySignature enforces the Reader's trust model for remote includes:
+// if a signing key is configured for remote, its fetched content must carry
+// a valid detached signature (inline `# signature:` header or sibling
+// `.sig` file); otherwise, --require-signed rejects the include outright.
+func (r *Reader) verifySignature(ctx context.Context, remote *RemoteNode, src *Source) error {
+	key, ok := r.keyring[remote.Location()]
+	if !ok {
+		if r.requireSigned {
+			return &errors.TaskfileSignatureError{URI: remote.Location(), Reason: "no trusted signing key configured for this include"}
+		}
+		return nil
+	}
+
+	sig, message, err := r.fetchSignature(ctx, remote, src)
+	if err != nil {
+		return &errors.TaskfileSignatureError{URI: remote.Location(), Reason: err.Error()}
+	}
+	if sig == nil {
+		return &errors.TaskfileSignatureError{URI: remote.Location(), Reason: "no signature found (neither inline nor a sibling .sig file)"}
+	}
+	if err := verifyDetachedSignature(key, message, sig); err != nil {
+		return &errors.TaskfileSignatureError{URI: remote.Location(), Reason: err.Error()}
+	}
+
+	return r.checkKeyRotation(remote.Location(), key)
+}
+
+// fetchSignature returns the normalized signature and the exact message it
+// was computed over, checking an inline header first and falling back to a
+// sibling "<uri>.sig" file. A nil signature with a nil error means none was
+// found. In --offline mode the sidecar ".sig" is never fetched live; it's
+// read from whatever a previous online run cached alongside the Taskfile
+// itself.
+func (r *Reader) fetchSignature(ctx context.Context, remote *RemoteNode, src *Source) ([]byte, []byte, error) {
+	if sig, rest, ok := splitInlineSignature(src.FileContent); ok {
+		return sig, rest, nil
+	}
+
+	sigNode, err := NewNode(r.logger, remote.Location()+".sig", "", r.insecure, r.timeout)
+	if err != nil {
+		return nil, nil, nil
+	}
+	sigRemote, ok := sigNode.(*RemoteNode)
+	if !ok {
+		return nil, nil, nil
+	}
+
+	cache, err := NewCache(r.tempDir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if r.offline {
+		cached, err := cache.read(*sigRemote)
+		if err != nil {
+			// No cached sidecar .sig is not fatal here, offline or not;
+			// verifySignature turns a nil signature into the right error
+			// depending on --require-signed.
+			return nil, nil, nil
+		}
+		cachedSrc, err := cached.Read()
+		if err != nil {
+			return nil, nil, nil
+		}
+		sig, err := decodeSignatureFile(cachedSrc.FileContent)
+		if err != nil {
+			return nil, nil, err
+		}
+		return sig, src.FileContent, nil
+	}
+
+	sigSrc, err := r.transfers.Fetch(ctx, sigRemote.Location(), func(ctx context.Context) (*Source, error) {
+		return sigRemote.ReadContext(ctx)
+	})
+	if err != nil {
+		// No sibling .sig file is not fatal here; verifySignature turns a
+		// nil signature into the right error depending on --require-signed.
+		return nil, nil, nil
+	}
+
+	// Cache the signature alongside the content so a later --offline run can
+	// still verify it.
+	if _, err := cache.write(*sigRemote, *sigSrc); err != nil {
+		return nil, nil, err
+	}
+
+	sig, err := decodeSignatureFile(sigSrc.FileContent)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sig, src.FileContent, nil
+}
+
+// checkKeyRotation compares key against the fingerprint last recorded for
+// uri, prompting the user the same way a changed checksum does if it has
+// changed, then records the new fingerprint.
+func (r *Reader) checkKeyRotation(uri string, key *SigningKey) error {
+	r.keyCacheMu.Lock()
+	defer r.keyCacheMu.Unlock()
+
+	kc, err := readKeyCache(r.keyCachePath())
+	if err != nil {
+		return err
+	}
+
+	if previous, ok := kc.Fingerprints[uri]; ok && previous != key.Fingerprint() {
+		prompt := fmt.Sprintf(taskfileKeyRotatedPrompt, uri)
+		if err := r.logger.Prompt(logger.Yellow, prompt, "n", "y", "yes"); err != nil {
+			return &errors.TaskfileSignatureError{URI: uri, Reason: "signing key rotated and the new key was not trusted"}
+		}
+	}
+
+	kc.Fingerprints[uri] = key.Fingerprint()
+	return kc.write()
+}
+
+func (r *Reader) keyCachePath() string {
+	return filepath.Join(r.tempDir, DefaultKeyCacheName)
+}